@@ -11,8 +11,12 @@ import (
 )
 
 var (
-	// Regex pattern for parsing and matching a function signature.
-	FunctionPattern = `function\s+(?<functionName>[a-zA-Z]{1}[a-zA-Z0-9\_]+)\s*\((?<input>[a-zA-Z0-9\s\,]*)\)\s+(?<mutability>pure|view)\s*returns\s+\((?<output>[a-zA-Z0-9\s\,]*)\)`
+	// Regex pattern for parsing and matching a function signature. The
+	// input/output groups allow parentheses and brackets so that tuples
+	// (e.g. "(uint256 a, address b)") and arrays (e.g. "bytes[]") can
+	// appear in the parameter list; parseParameters is responsible for
+	// splitting those groups correctly.
+	FunctionPattern = `function\s+(?P<functionName>[a-zA-Z]{1}[a-zA-Z0-9\_]+)\s*\((?P<input>[a-zA-Z0-9\s\,\[\]\(\)]*)\)\s+(?P<mutability>pure|view)\s*returns\s+\((?P<output>[a-zA-Z0-9\s\,\[\]\(\)]*)\)`
 	FunctionRegex   = regexp.MustCompile(FunctionPattern)
 )
 
@@ -68,47 +72,198 @@ func ParseFunction(str string) (*abi.Method, error) {
 }
 
 func parseParameters(paramStr string) ([]abi.Argument, error) {
-	// split at commas
-	params := strings.Split(paramStr, ",")
+	segments := splitTopLevel(paramStr)
 
 	var paramNames = "abcdefghijklmnopqrstuvwxyz"
 
 	var abiArgs []abi.Argument
 
-	for i, param := range params {
-		param = strings.TrimSpace(param)
+	for i, segment := range segments {
+		segment = strings.TrimSpace(segment)
 
-		if param == "" {
+		if segment == "" {
 			continue
 		}
 
-		fields := strings.Fields(param)
+		component, err := parseComponent(segment, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if component.Name == "" {
+			component.Name = paramNames[i : i+1]
+		}
+
+		abiType, err := abi.NewType(component.Type, component.Type, component.Components)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter type: %s", component.Type)
+		}
+
+		abiArgs = append(abiArgs, abi.Argument{
+			Name:    component.Name,
+			Type:    abiType,
+			Indexed: false,
+		})
+	}
+
+	return abiArgs, nil
+}
 
-		if len(fields) < 1 || len(fields) > 2 {
-			return nil, fmt.Errorf("invalid parameter format: %s", param)
+// splitTopLevel splits s on commas that are not nested inside parentheses
+// (tuples) or brackets (arrays), e.g. "(uint256,address) a, bytes[] b"
+// splits into ["(uint256,address) a", " bytes[] b"].
+func splitTopLevel(s string) []string {
+	var parts []string
+
+	depth := 0
+	start := 0
+
+	for i, c := range s {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
 		}
+	}
 
-		paramType := fields[0]
-		paramName := paramNames[i : i+1]
+	parts = append(parts, s[start:])
 
-		if len(fields) == 2 {
-			paramName = fields[1]
+	return parts
+}
+
+// parseComponent parses a single "type [location] [name]" parameter
+// segment, such as "bytes[] data", "uint256 a", or
+// "(uint256 a, address b) memory record". Unnamed tuple fields are named
+// arg0, arg1, ... by their position in the tuple; idx is not used.
+func parseComponent(segment string, idx int) (abi.ArgumentMarshaling, error) {
+	typeStr, name, err := splitTypeAndName(segment)
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+
+	if !strings.HasPrefix(typeStr, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: typeStr}, nil
+	}
+
+	depth := 0
+	end := -1
+
+	for i, c := range typeStr {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+		if end != -1 {
+			break
 		}
+	}
 
-		abiType, err := abi.NewType(paramType, paramType, nil)
+	if end == -1 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unbalanced parentheses in type: %s", typeStr)
+	}
+
+	arraySuffix := typeStr[end+1:]
 
+	var components []abi.ArgumentMarshaling
+
+	for i, fieldSegment := range splitTopLevel(typeStr[1:end]) {
+		fieldSegment = strings.TrimSpace(fieldSegment)
+		if fieldSegment == "" {
+			continue
+		}
+
+		field, err := parseComponent(fieldSegment, i)
 		if err != nil {
-			return nil, fmt.Errorf("invalid parameter type: %s", paramType)
+			return abi.ArgumentMarshaling{}, err
+		}
+		if field.Name == "" {
+			field.Name = fmt.Sprintf("arg%d", i)
 		}
 
-		abiArg := abi.Argument{
-			Name:    paramName,
-			Type:    abiType, // Type parsing can be added here if needed
-			Indexed: false,
+		components = append(components, field)
+	}
+
+	return abi.ArgumentMarshaling{
+		Name:       name,
+		Type:       "tuple" + arraySuffix,
+		Components: components,
+	}, nil
+}
+
+// splitTypeAndName separates a parameter's type (which may be a
+// parenthesized tuple, optionally followed by an array suffix) from its
+// name, dropping Solidity data-location keywords such as memory/calldata
+// in between.
+func splitTypeAndName(segment string) (typeStr, name string, err error) {
+	segment = strings.TrimSpace(segment)
+	if segment == "" {
+		return "", "", fmt.Errorf("invalid parameter format: %s", segment)
+	}
+
+	var rest string
+
+	if segment[0] == '(' {
+		depth := 0
+		end := -1
+
+		for i, c := range segment {
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+			if end != -1 {
+				break
+			}
+		}
+
+		if end == -1 {
+			return "", "", fmt.Errorf("unbalanced parentheses in: %s", segment)
+		}
+
+		i := end + 1
+		for i < len(segment) && segment[i] == '[' {
+			j := strings.IndexByte(segment[i:], ']')
+			if j == -1 {
+				return "", "", fmt.Errorf("unbalanced brackets in: %s", segment)
+			}
+			i += j + 1
 		}
 
-		abiArgs = append(abiArgs, abiArg)
+		typeStr = segment[:i]
+		rest = strings.TrimSpace(segment[i:])
+	} else {
+		fields := strings.Fields(segment)
+		if len(fields) == 0 {
+			return "", "", fmt.Errorf("invalid parameter format: %s", segment)
+		}
+		typeStr = fields[0]
+		rest = strings.Join(fields[1:], " ")
 	}
 
-	return abiArgs, nil
+	for _, word := range strings.Fields(rest) {
+		switch word {
+		case "memory", "calldata", "storage", "indexed":
+			continue
+		default:
+			name = word
+		}
+	}
+
+	return typeStr, name, nil
 }
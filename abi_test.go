@@ -0,0 +1,105 @@
+package ccip
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseFunction(t *testing.T) {
+	method, err := ParseFunction("function addr(bytes32 node) view returns (address)")
+	if err != nil {
+		t.Fatalf("ParseFunction failed: %v", err)
+	}
+
+	if method.Name != "addr" {
+		t.Fatalf("method name = %q, want %q", method.Name, "addr")
+	}
+	if len(method.Inputs) != 1 || method.Inputs[0].Name != "node" {
+		t.Fatalf("unexpected inputs: %+v", method.Inputs)
+	}
+	if len(method.Outputs) != 1 {
+		t.Fatalf("unexpected outputs: %+v", method.Outputs)
+	}
+}
+
+func TestParseFunctionInvalidSignature(t *testing.T) {
+	if _, err := ParseFunction("not a function"); err != ErrInvalidFunctionSignature {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidFunctionSignature)
+	}
+}
+
+func TestParseFunctionArray(t *testing.T) {
+	method, err := ParseFunction("function multicall(bytes[] data) view returns (bytes[] results)")
+	if err != nil {
+		t.Fatalf("ParseFunction failed: %v", err)
+	}
+
+	if len(method.Inputs) != 1 || method.Inputs[0].Type.String() != "bytes[]" {
+		t.Fatalf("unexpected inputs: %+v", method.Inputs)
+	}
+	if len(method.Outputs) != 1 || method.Outputs[0].Type.String() != "bytes[]" {
+		t.Fatalf("unexpected outputs: %+v", method.Outputs)
+	}
+}
+
+func TestParseFunctionTuple(t *testing.T) {
+	method, err := ParseFunction("function setRecord((uint256 x, address y) record) view returns (bool ok)")
+	if err != nil {
+		t.Fatalf("ParseFunction failed: %v", err)
+	}
+
+	if len(method.Inputs) != 1 || method.Inputs[0].Name != "record" {
+		t.Fatalf("unexpected inputs: %+v", method.Inputs)
+	}
+	if method.Inputs[0].Type.String() != "(uint256,address)" {
+		t.Fatalf("tuple type = %s, want (uint256,address)", method.Inputs[0].Type.String())
+	}
+}
+
+// TestHandleDispatchesArraySignature exercises the bug chunk0-3 hit in
+// practice: before parseParameters learned to handle brackets, Handle
+// could never register (or dispatch through) a signature like
+// multicall's bytes[] parameter.
+func TestHandleDispatchesArraySignature(t *testing.T) {
+	r := NewCCIPReadResolver()
+
+	err := r.Handle("function multicall(bytes[] data) view returns (bytes[] results)", func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error) {
+		dataVar, _ := request.Var("data")
+		data, ok := dataVar.([][]byte)
+		if !ok {
+			t.Fatalf("unexpected type for data parameter: %T", dataVar)
+		}
+		return []interface{}{data}, nil
+	})
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	method := findRegisteredMethod(t, r, "multicall")
+	handler := findRegisteredHandler(t, r, "multicall")
+
+	callData, err := method.Inputs.Pack([][]byte{[]byte("hello")})
+	if err != nil {
+		t.Fatalf("failed to pack call: %v", err)
+	}
+
+	inputMap := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(inputMap, callData); err != nil {
+		t.Fatalf("failed to unpack call: %v", err)
+	}
+
+	req := &CCIPReadRequest{
+		Method: method,
+		Input:  []Variable{{Name: "data", Value: inputMap["data"]}},
+	}
+
+	outputs, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+
+	results, ok := outputs[0].([][]byte)
+	if !ok || len(results) != 1 || string(results[0]) != "hello" {
+		t.Fatalf("unexpected outputs: %+v", outputs)
+	}
+}
@@ -0,0 +1,47 @@
+package ccip
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ctxKey int
+
+const senderCtxKey ctxKey = iota
+
+// HandlerTimeout wraps the context passed to a CCIPReadHandler with a
+// deadline of d, so a handler's backend calls are canceled if they run too
+// long, independent of whether the originating HTTP client has already
+// disconnected.
+func HandlerTimeout(d time.Duration) Option {
+	return func(r *CCIPReadResolver) {
+		r.handlerTimeout = d
+	}
+}
+
+// SenderFromContext returns the validated sender address for the current
+// request, if the resolver was configured with a SenderValidator and the
+// request carried one. Handlers can use this to make access-control
+// decisions without a second parameter.
+func SenderFromContext(ctx context.Context) (common.Address, bool) {
+	sender, ok := ctx.Value(senderCtxKey).(common.Address)
+	return sender, ok
+}
+
+func contextWithSender(ctx context.Context, sender common.Address) context.Context {
+	return context.WithValue(ctx, senderCtxKey, sender)
+}
+
+// callHandler invokes handler with ctx, applying HandlerTimeout if one was
+// configured.
+func (r *CCIPReadResolver) callHandler(ctx context.Context, handler CCIPReadHandler, req *CCIPReadRequest) ([]interface{}, error) {
+	if r.handlerTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.handlerTimeout)
+		defer cancel()
+	}
+
+	return handler(ctx, req)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -47,13 +48,13 @@ func main() {
 	resolver := ccip.NewCCIPReadResolver()
 
 	// Handle addr(bytes32) function - returns the address for a given namehash
-	resolver.Handle("function addr(bytes32 namehash) view returns (address)", func(request *ccip.CCIPReadRequest) ([]interface{}, error) {
+	resolver.Handle("function addr(bytes32 namehash) view returns (address)", func(ctx context.Context, request *ccip.CCIPReadRequest) ([]interface{}, error) {
 		namehashVar, ok := request.Var("namehash")
 		if !ok {
 			return nil, fmt.Errorf("namehash parameter not found")
 		}
 
-		namehash := namehashVar.Value.([32]byte)
+		namehash := namehashVar.([32]byte)
 		namehashHex := "0x" + common.Bytes2Hex(namehash[:])
 
 		var record AddressRecord
@@ -71,7 +72,7 @@ func main() {
 	})
 
 	// Handle text(bytes32, string) function - returns text records
-	resolver.Handle("function text(bytes32 namehash, string key) view returns (string)", func(request *ccip.CCIPReadRequest) ([]interface{}, error) {
+	resolver.Handle("function text(bytes32 namehash, string key) view returns (string)", func(ctx context.Context, request *ccip.CCIPReadRequest) ([]interface{}, error) {
 		namehashVar, ok := request.Var("namehash")
 		if !ok {
 			return nil, fmt.Errorf("namehash parameter not found")
@@ -82,9 +83,9 @@ func main() {
 			return nil, fmt.Errorf("key parameter not found")
 		}
 
-		namehash := namehashVar.Value.([32]byte)
+		namehash := namehashVar.([32]byte)
 		namehashHex := "0x" + common.Bytes2Hex(namehash[:])
-		key := keyVar.Value.(string)
+		key := keyVar.(string)
 
 		var textRecord TextRecord
 		result := db.Where("namehash = ? AND key = ?", namehashHex, key).First(&textRecord)
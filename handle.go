@@ -0,0 +1,56 @@
+package ccip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Handle registers a handler for a given function signature, e.g.
+// "function addr(bytes32 node) view returns (address)". ParseFunction
+// understands tuples and arrays (e.g. "(uint256 a, address b)",
+// "bytes[]"), so this also covers those cases; for registering directly
+// from a compiled contract's ABI JSON, use HandleABI or HandleMethod
+// instead.
+func (r *CCIPReadResolver) Handle(sig string, handler CCIPReadHandler) error {
+	method, err := ParseFunction(sig)
+	if err != nil {
+		return err
+	}
+
+	return r.HandleMethod(*method, handler)
+}
+
+// HandleABI registers a handler for methodName using a full Solidity ABI
+// JSON document, such as the one produced by solc or go-ethereum's abigen.
+// It reuses go-ethereum's ABI parser rather than the local signature-string
+// regex, so it accepts any method that go-ethereum's parser does, including
+// tuples and arrays of structs.
+func (r *CCIPReadResolver) HandleABI(abiJSON string, methodName string, handler CCIPReadHandler) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	method, ok := parsed.Methods[methodName]
+	if !ok {
+		return fmt.Errorf("method %q not found in ABI", methodName)
+	}
+
+	return r.HandleMethod(method, handler)
+}
+
+// HandleMethod registers a handler for an already-constructed abi.Method,
+// e.g. one obtained from HandleABI or from abi.JSON directly.
+func (r *CCIPReadResolver) HandleMethod(m abi.Method, handler CCIPReadHandler) error {
+	byte4Sig := binary.BigEndian.Uint32(m.ID)
+
+	r.handlers[byte4Sig] = registeredHandler{
+		method:  &m,
+		handler: handler,
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const sumArrayABI = `[{
+	"type": "function",
+	"name": "sum",
+	"stateMutability": "view",
+	"inputs": [{"name": "values", "type": "uint256[]"}],
+	"outputs": [{"name": "total", "type": "uint256"}]
+}]`
+
+// TestHandleABIArrayDispatch registers a method with an array-typed
+// parameter (uint256[]) via HandleABI -- a type the string-based Handle
+// could not parse before abi.go's parseParameters learned to handle
+// brackets -- and dispatches an ABI-encoded call through the registered
+// handler end to end.
+func TestHandleABIArrayDispatch(t *testing.T) {
+	r := NewCCIPReadResolver()
+
+	err := r.HandleABI(sumArrayABI, "sum", func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error) {
+		valuesVar, ok := request.Var("values")
+		if !ok {
+			return nil, fmt.Errorf("values parameter not found")
+		}
+
+		values, ok := valuesVar.([]*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for values parameter: %T", valuesVar)
+		}
+
+		total := new(big.Int)
+		for _, v := range values {
+			total.Add(total, v)
+		}
+
+		return []interface{}{total}, nil
+	})
+	if err != nil {
+		t.Fatalf("HandleABI failed: %v", err)
+	}
+
+	method := findRegisteredMethod(t, r, "sum")
+
+	callData, err := method.Inputs.Pack([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(39)})
+	if err != nil {
+		t.Fatalf("failed to pack call: %v", err)
+	}
+
+	inputMap := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(inputMap, callData); err != nil {
+		t.Fatalf("failed to unpack call: %v", err)
+	}
+
+	req := &CCIPReadRequest{
+		Method: method,
+		Input:  []Variable{{Name: "values", Value: inputMap["values"]}},
+	}
+
+	handler := findRegisteredHandler(t, r, "sum")
+
+	outputs, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+
+	total, ok := outputs[0].(*big.Int)
+	if !ok || total.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("outputs = %+v, want [42]", outputs)
+	}
+}
+
+func findRegisteredMethod(t *testing.T, r *CCIPReadResolver, name string) *abi.Method {
+	t.Helper()
+	for _, h := range r.handlers {
+		if h.method.Name == name {
+			return h.method
+		}
+	}
+	t.Fatalf("method %q not registered", name)
+	return nil
+}
+
+func findRegisteredHandler(t *testing.T, r *CCIPReadResolver, name string) CCIPReadHandler {
+	t.Helper()
+	for _, h := range r.handlers {
+		if h.method.Name == name {
+			return h.handler
+		}
+	}
+	t.Fatalf("method %q not registered", name)
+	return nil
+}
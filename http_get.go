@@ -0,0 +1,91 @@
+package ccip
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// URLTemplate configures the GET route template advertised by the gateway,
+// as described by EIP-3668. pattern must contain a {sender} and a {data}
+// placeholder, e.g. "/gateway/{sender}/{data}.json". When set, ServeHTTP
+// additionally accepts GET requests whose path matches this template,
+// substituting sender and data into the same dispatch pipeline used by
+// POST requests.
+func URLTemplate(pattern string) Option {
+	regex := compileURLTemplate(pattern)
+
+	return func(r *CCIPReadResolver) {
+		r.urlTemplate = pattern
+		r.urlTemplateRegex = regex
+	}
+}
+
+func compileURLTemplate(pattern string) *regexp.Regexp {
+	escaped := regexp.QuoteMeta(pattern)
+	escaped = strings.ReplaceAll(escaped, regexp.QuoteMeta("{sender}"), `(?P<sender>[^/]+)`)
+	escaped = strings.ReplaceAll(escaped, regexp.QuoteMeta("{data}"), `(?P<data>[^/]+)`)
+	return regexp.MustCompile("^" + escaped + "$")
+}
+
+func (r *CCIPReadResolver) serveGet(rw http.ResponseWriter, req *http.Request) {
+	if r.urlTemplateRegex == nil {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	match := r.urlTemplateRegex.FindStringSubmatch(req.URL.Path)
+
+	if match == nil {
+		http.Error(rw, "not found", http.StatusNotFound)
+		return
+	}
+
+	var sender, data string
+
+	for i, name := range r.urlTemplateRegex.SubexpNames() {
+		switch name {
+		case "sender":
+			sender = match[i]
+		case "data":
+			data = match[i]
+		}
+	}
+
+	sender, err := url.QueryUnescape(sender)
+	if err != nil {
+		http.Error(rw, "invalid sender parameter", http.StatusBadRequest)
+		return
+	}
+
+	data, err = url.QueryUnescape(data)
+	if err != nil {
+		http.Error(rw, "invalid data parameter", http.StatusBadRequest)
+		return
+	}
+
+	r.dispatch(rw, req, sender, data)
+}
+
+// RegisterRoute registers the resolver on mux at the path prefix implied by
+// its configured URLTemplate, so callers don't have to hand-wire routing
+// for the GET endpoint themselves. URLTemplate must be set first.
+func (r *CCIPReadResolver) RegisterRoute(mux *http.ServeMux) error {
+	if r.urlTemplate == "" {
+		return fmt.Errorf("ccip: RegisterRoute requires URLTemplate to be configured")
+	}
+
+	prefix := r.urlTemplate
+	if idx := strings.Index(prefix, "{"); idx >= 0 {
+		prefix = prefix[:idx]
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	mux.Handle(prefix, r)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestServeGetURLTemplate(t *testing.T) {
+	want := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	r := NewCCIPReadResolver(URLTemplate("/gateway/{sender}/{data}.json"))
+
+	method, err := ParseFunction("function addr(bytes32 node) view returns (address)")
+	if err != nil {
+		t.Fatalf("failed to parse function: %v", err)
+	}
+
+	if err := r.HandleMethod(*method, func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error) {
+		return []interface{}{want}, nil
+	}); err != nil {
+		t.Fatalf("HandleMethod failed: %v", err)
+	}
+
+	node := [32]byte{0x01}
+	callData, err := method.Inputs.Pack(node)
+	if err != nil {
+		t.Fatalf("failed to pack call data: %v", err)
+	}
+	callData = append(method.ID, callData...)
+
+	url := "/gateway/0x1234567890123456789012345678901234567890/" + hexutil.Encode(callData) + ".json"
+
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rw := httptest.NewRecorder()
+
+	r.ServeHTTP(rw, req)
+
+	if rw.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rw.Code, rw.Body.String())
+	}
+
+	var resp struct {
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(rw.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	outputData, err := hexutil.Decode(resp.Data)
+	if err != nil {
+		t.Fatalf("failed to decode output data: %v", err)
+	}
+
+	values, err := method.Outputs.Unpack(outputData)
+	if err != nil {
+		t.Fatalf("failed to unpack output: %v", err)
+	}
+
+	if got := values[0].(common.Address); got != want {
+		t.Fatalf("addr result = %s, want %s", got, want)
+	}
+}
+
+func TestServeGetWithoutURLTemplate(t *testing.T) {
+	r := NewCCIPReadResolver()
+
+	req := httptest.NewRequest(http.MethodGet, "/gateway/0x1234567890123456789012345678901234567890/0x.json", nil)
+	rw := httptest.NewRecorder()
+
+	r.ServeHTTP(rw, req)
+
+	if rw.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", rw.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,251 @@
+// Package middleware provides a small set of http.Handler middlewares for
+// production CCIP-Read gateways: request correlation IDs, CORS (CCIP-Read
+// clients are usually browsers/wallets performing cross-origin fetches from
+// dapp domains), per-sender rate limiting, and Prometheus metrics. They are
+// meant to be registered on a resolver via ccip.Use.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type contextKey string
+
+const requestIDContextKey contextKey = "ccip-request-id"
+
+// RequestID injects an X-Request-ID response header and propagates the same
+// ID into the request context, so handlers and logs can correlate a single
+// gateway call. An incoming X-Request-ID header is reused if present.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get("X-Request-ID")
+		if id == "" {
+			id = newRequestID()
+		}
+
+		rw.Header().Set("X-Request-ID", id)
+
+		ctx := context.WithValue(req.Context(), requestIDContextKey, id)
+		next.ServeHTTP(rw, req.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID injected by RequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey).(string)
+	return id, ok
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// CORS allows the given origins to perform cross-origin CCIP-Read requests.
+// With no origins given, it allows all origins ("*"), which is appropriate
+// for a public gateway serving arbitrary dapps.
+func CORS(allowedOrigins ...string) func(http.Handler) http.Handler {
+	allowAll := len(allowedOrigins) == 0
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		origins[o] = true
+		if o == "*" {
+			allowAll = true
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			origin := req.Header.Get("Origin")
+
+			switch {
+			case allowAll:
+				rw.Header().Set("Access-Control-Allow-Origin", "*")
+			case origin != "" && origins[origin]:
+				rw.Header().Set("Access-Control-Allow-Origin", origin)
+				rw.Header().Set("Vary", "Origin")
+			}
+
+			rw.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			rw.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+			if req.Method == http.MethodOptions {
+				rw.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+// RateLimit throttles requests with a token bucket, allowing bursts up to
+// burst and refilling at rate tokens per second. Requests are bucketed per
+// sender address: the "sender" JSON field on a POST body, or, for other
+// methods, the first path segment that is a hex address (the {sender}
+// segment of the usual GET template; the resolver's URLTemplate is not
+// consulted).
+// The sender field is optional per the CCIP-Read spec, so requests that
+// omit it are bucketed by remote IP instead of being waved through
+// unthrottled.
+func RateLimit(rate float64, burst int) func(http.Handler) http.Handler {
+	limiter := &rateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*tokenBucket),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if !limiter.allow(bucketKey(req)) {
+				http.Error(rw, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+// bucketKey returns the rate-limit bucket a request should be charged
+// against: the sender address if one was supplied, otherwise the remote IP.
+func bucketKey(req *http.Request) string {
+	if sender, ok := senderFromRequest(req); ok {
+		return sender.Hex()
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func senderFromRequest(req *http.Request) (common.Address, bool) {
+	if req.Method == http.MethodPost {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return common.Address{}, false
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		var parsed struct {
+			Sender string `json:"sender"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil || parsed.Sender == "" {
+			return common.Address{}, false
+		}
+		return common.HexToAddress(parsed.Sender), true
+	}
+
+	for _, part := range strings.Split(strings.Trim(req.URL.Path, "/"), "/") {
+		if common.IsHexAddress(part) {
+			return common.HexToAddress(part), true
+		}
+	}
+
+	return common.Address{}, false
+}
+
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	lastFill time.Time
+}
+
+type rateLimiter struct {
+	rate  float64
+	burst int
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.burst), lastFill: time.Now()}
+		l.buckets[key] = b
+	}
+	l.mu.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.lastFill).Seconds() * l.rate
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.lastFill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ccip_read",
+		Name:      "request_duration_seconds",
+		Help:      "CCIP-Read gateway request latency in seconds.",
+	}, []string{"method", "status"})
+
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ccip_read",
+		Name:      "request_errors_total",
+		Help:      "Total CCIP-Read gateway requests that returned a 4xx or 5xx status.",
+	}, []string{"method", "status"})
+)
+
+func init() {
+	prometheus.MustRegister(requestDuration, requestErrors)
+}
+
+// Prometheus records per-method request latency and error counts on the
+// default Prometheus registry.
+func Prometheus(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: rw, status: http.StatusOK}
+
+		next.ServeHTTP(sw, req)
+
+		status := strconv.Itoa(sw.status)
+		requestDuration.WithLabelValues(req.Method, status).Observe(time.Since(start).Seconds())
+		if sw.status >= 400 {
+			requestErrors.WithLabelValues(req.Method, status).Inc()
+		}
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
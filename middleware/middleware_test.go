@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimitBucketsBySender(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := RateLimit(0, 1)(next)
+
+	senderA := []byte(`{"sender":"0x1111111111111111111111111111111111111111","data":"0x"}`)
+	senderB := []byte(`{"sender":"0x2222222222222222222222222222222222222222","data":"0x"}`)
+
+	// First request from sender A consumes its only token.
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(senderA)))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("sender A first request: status = %d, want %d", rw.Code, http.StatusOK)
+	}
+
+	// Second request from sender A is throttled; its bucket has no refill
+	// since rate is 0.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(senderA)))
+	if rw.Code != http.StatusTooManyRequests {
+		t.Fatalf("sender A second request: status = %d, want %d", rw.Code, http.StatusTooManyRequests)
+	}
+
+	// A different sender has its own bucket and is unaffected.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(senderB)))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("sender B first request: status = %d, want %d", rw.Code, http.StatusOK)
+	}
+}
+
+func TestRateLimitBucketsSenderlessRequestsByIP(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := RateLimit(0, 1)(next)
+
+	senderless := []byte(`{"data":"0x"}`)
+
+	newReq := func(remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(senderless))
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	// First senderless request from an IP consumes its bucket's only token.
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, newReq("203.0.113.1:4000"))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("first request: status = %d, want %d", rw.Code, http.StatusOK)
+	}
+
+	// A second senderless request from the same IP is throttled, proving
+	// senderless traffic isn't waved through unthrottled.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, newReq("203.0.113.1:4001"))
+	if rw.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request from same IP: status = %d, want %d", rw.Code, http.StatusTooManyRequests)
+	}
+
+	// A senderless request from a different IP gets its own bucket.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, newReq("203.0.113.2:4000"))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("request from different IP: status = %d, want %d", rw.Code, http.StatusOK)
+	}
+}
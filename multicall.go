@@ -0,0 +1,155 @@
+package ccip
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errorStringArgs = abi.Arguments{{Type: mustType("string")}}
+
+// multicallMethod builds the multicall(bytes[]) abi.Method directly from
+// abi.NewMethod/abi.Arguments, the same way signed.go builds its envelope
+// type, rather than round-tripping through the signature-string parser.
+func multicallMethod() abi.Method {
+	bytesArrayType := mustType("bytes[]")
+
+	inputs := abi.Arguments{{Name: "data", Type: bytesArrayType}}
+	outputs := abi.Arguments{{Name: "results", Type: bytesArrayType}}
+
+	return abi.NewMethod("multicall", "multicall", abi.Function, "view", false, false, inputs, outputs)
+}
+
+// resolveMethod builds the ENSIP-10 resolve(bytes,bytes) abi.Method
+// directly from abi.NewMethod/abi.Arguments, as above.
+func resolveMethod() abi.Method {
+	bytesType := mustType("bytes")
+
+	inputs := abi.Arguments{
+		{Name: "name", Type: bytesType},
+		{Name: "data", Type: bytesType},
+	}
+	outputs := abi.Arguments{{Name: "result", Type: bytesType}}
+
+	return abi.NewMethod("resolve", "resolve", abi.Function, "view", false, false, inputs, outputs)
+}
+
+// encodeError packs err into Solidity's standard Error(string) revert
+// encoding, so a failing call inside a multicall batch can be reported
+// without aborting the rest of the batch.
+func encodeError(err error) []byte {
+	selector := crypto.Keccak256([]byte("Error(string)"))[:4]
+
+	packed, packErr := errorStringArgs.Pack(err.Error())
+	if packErr != nil {
+		return selector
+	}
+
+	return append(selector, packed...)
+}
+
+// HandleMulticall registers the standard multicall(bytes[]) entry point
+// used by ENS offchain resolvers. Each element of the batch is dispatched
+// through the resolver's normally registered handlers exactly as if it had
+// arrived as its own top-level call. A failing sub-call does not abort the
+// rest of the batch; its error is packed using Solidity's standard
+// Error(string) revert encoding and slotted into the results array.
+func (r *CCIPReadResolver) HandleMulticall() error {
+	return r.HandleMethod(multicallMethod(), func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error) {
+		callsVar, ok := request.Var("data")
+		if !ok {
+			return nil, fmt.Errorf("data parameter not found")
+		}
+
+		calls, ok := callsVar.([][]byte)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for data parameter: %T", callsVar)
+		}
+
+		results := make([][]byte, len(calls))
+
+		for i, call := range calls {
+			result, err := r.invokeCall(ctx, call)
+			if err != nil {
+				results[i] = encodeError(err)
+				continue
+			}
+			results[i] = result
+		}
+
+		return []interface{}{results}, nil
+	})
+}
+
+// WrappedResolve registers the ENSIP-10 resolve(bytes,bytes) entry point
+// used for ENS wildcard resolution. The DNS-wire-format name parameter is
+// accepted but not otherwise interpreted; the inner call in data is
+// dispatched through the resolver's registered handlers (including
+// HandleMulticall, if registered) exactly as if it had arrived directly.
+func (r *CCIPReadResolver) WrappedResolve() error {
+	return r.HandleMethod(resolveMethod(), func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error) {
+		dataVar, ok := request.Var("data")
+		if !ok {
+			return nil, fmt.Errorf("data parameter not found")
+		}
+
+		data, ok := dataVar.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for data parameter: %T", dataVar)
+		}
+
+		result, err := r.invokeCall(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+
+		return []interface{}{result}, nil
+	})
+}
+
+// invokeCall dispatches a single ABI-encoded call through the resolver's
+// registered handlers and returns the ABI-encoded return value, without
+// going through HTTP transport encoding. It is used by HandleMulticall and
+// WrappedResolve to fan out a batch of sub-calls.
+func (r *CCIPReadResolver) invokeCall(ctx context.Context, data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("data field too short")
+	}
+
+	byte4Sig := binary.BigEndian.Uint32(data[:4])
+
+	registered, ok := r.handlers[byte4Sig]
+	if !ok {
+		return nil, fmt.Errorf("function not found")
+	}
+
+	inputMap := make(map[string]interface{})
+
+	if err := registered.method.Inputs.UnpackIntoMap(inputMap, data[4:]); err != nil {
+		return nil, fmt.Errorf("failed to unpack input parameters: %w", err)
+	}
+
+	var inputVars []Variable
+
+	for _, arg := range registered.method.Inputs {
+		inputVars = append(inputVars, Variable{
+			Name:  arg.Name,
+			Value: inputMap[arg.Name],
+		})
+	}
+
+	ccipReadReq := &CCIPReadRequest{
+		Method: registered.method,
+		Input:  inputVars,
+	}
+
+	outputs, err := r.callHandler(ctx, registered.handler, ccipReadReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return registered.method.Outputs.PackValues(outputs)
+}
@@ -0,0 +1,76 @@
+package ccip
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestHandleMulticallDispatchesBatch(t *testing.T) {
+	r := NewCCIPReadResolver()
+
+	addrSig, err := ParseFunction("function addr(bytes32 node) view returns (address)")
+	if err != nil {
+		t.Fatalf("failed to parse addr signature: %v", err)
+	}
+
+	want := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	if err := r.HandleMethod(*addrSig, func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error) {
+		return []interface{}{want}, nil
+	}); err != nil {
+		t.Fatalf("HandleMethod failed: %v", err)
+	}
+
+	if err := r.HandleMulticall(); err != nil {
+		t.Fatalf("HandleMulticall failed: %v", err)
+	}
+
+	node := [32]byte{0x01}
+	call, err := addrSig.Inputs.Pack(node)
+	if err != nil {
+		t.Fatalf("failed to pack addr call: %v", err)
+	}
+	call = append(addrSig.ID, call...)
+
+	batch, err := multicallMethod().Inputs.Pack([][]byte{call})
+	if err != nil {
+		t.Fatalf("failed to pack multicall batch: %v", err)
+	}
+
+	registered, ok := r.handlers[binary.BigEndian.Uint32(multicallMethod().ID)]
+	if !ok {
+		t.Fatal("multicall method not registered")
+	}
+
+	inputMap := make(map[string]interface{})
+	if err := registered.method.Inputs.UnpackIntoMap(inputMap, batch); err != nil {
+		t.Fatalf("failed to unpack multicall batch: %v", err)
+	}
+
+	req := &CCIPReadRequest{
+		Method: registered.method,
+		Input:  []Variable{{Name: "data", Value: inputMap["data"]}},
+	}
+
+	outputs, err := registered.handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("multicall handler failed: %v", err)
+	}
+
+	results, ok := outputs[0].([][]byte)
+	if !ok || len(results) != 1 {
+		t.Fatalf("unexpected multicall results: %+v", outputs)
+	}
+
+	values, err := addrSig.Outputs.Unpack(results[0])
+	if err != nil {
+		t.Fatalf("failed to unpack addr result: %v", err)
+	}
+
+	if got := values[0].(common.Address); got != want {
+		t.Fatalf("addr result = %s, want %s", got, want)
+	}
+}
@@ -1,10 +1,14 @@
 package ccip
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,7 +25,7 @@ func Gateways(gateways ...common.Address) Option {
 					return nil
 				}
 			}
-			return nil
+			return fmt.Errorf("sender %s is not an allowed gateway", sender)
 		}
 	}
 }
@@ -38,6 +42,17 @@ func OutputEncoding(encoder OutputEncoder) Option {
 	}
 }
 
+// Use appends a middleware to the chain wrapped around the resolver's
+// request handling. Middlewares run in the order they were added, outermost
+// first; sender validation and function dispatch are always the innermost
+// handler. See the ccip/middleware package for ready-made middlewares such
+// as CORS, RateLimit, RequestID and Prometheus.
+func Use(mw func(http.Handler) http.Handler) Option {
+	return func(r *CCIPReadResolver) {
+		r.middlewares = append(r.middlewares, mw)
+	}
+}
+
 type CCIPReadRequest struct {
 	Method *abi.Method
 	Input  []Variable
@@ -52,8 +67,11 @@ func (r *CCIPReadRequest) Var(name string) (interface{}, bool) {
 	return nil, false
 }
 
-// CCIPReadHandler defines the function signature for handling CCIP read requests.
-type CCIPReadHandler func(request *CCIPReadRequest) ([]interface{}, error)
+// CCIPReadHandler defines the function signature for handling CCIP read
+// requests. ctx carries the request's deadline and cancellation (see
+// HandlerTimeout) and, once sender validation has run, the validated sender
+// address (see SenderFromContext).
+type CCIPReadHandler func(ctx context.Context, request *CCIPReadRequest) ([]interface{}, error)
 
 // SenderValidator defines the function signature for validating the sender address.
 type SenderValidator func(sender common.Address) error
@@ -72,24 +90,16 @@ type CCIPReadResolver struct {
 	senderValidator SenderValidator
 
 	outputEncoder OutputEncoder
-}
 
-// Handle registers a handler for a given function signature.
-func (r *CCIPReadResolver) Handle(sig string, handler CCIPReadHandler) error {
-	method, err := ParseFunction(sig)
+	signer *responseSigner
 
-	if err != nil {
-		return err
-	}
-
-	byte4Sig := binary.BigEndian.Uint32(method.ID)
+	urlTemplate      string
+	urlTemplateRegex *regexp.Regexp
 
-	r.handlers[byte4Sig] = registeredHandler{
-		method:  method,
-		handler: handler,
-	}
+	middlewares []func(http.Handler) http.Handler
+	chain       http.Handler
 
-	return nil
+	handlerTimeout time.Duration
 }
 
 type HttpCCIPReadRequest struct {
@@ -97,13 +107,25 @@ type HttpCCIPReadRequest struct {
 	Sender string `json:"sender,omitempty"`
 }
 
+// ServeHTTP runs the request through the configured middleware chain (see
+// Use), with sender validation and function dispatch as the innermost
+// handler.
 func (r *CCIPReadResolver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	r.chain.ServeHTTP(rw, req)
+}
 
-	if req.Method != http.MethodPost {
+func (r *CCIPReadResolver) route(rw http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		r.servePost(rw, req)
+	case http.MethodGet:
+		r.serveGet(rw, req)
+	default:
 		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
-		return
 	}
+}
 
+func (r *CCIPReadResolver) servePost(rw http.ResponseWriter, req *http.Request) {
 	body, err := io.ReadAll(req.Body)
 
 	if err != nil {
@@ -120,18 +142,24 @@ func (r *CCIPReadResolver) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	if r.senderValidator != nil && ccipReq.Sender != "" {
-		senderAddr := common.HexToAddress(ccipReq.Sender)
+	r.dispatch(rw, req, ccipReq.Sender, ccipReq.Data)
+}
+
+func (r *CCIPReadResolver) dispatch(rw http.ResponseWriter, req *http.Request, sender, dataHex string) {
+	ctx := req.Context()
 
-		err := r.senderValidator(senderAddr)
+	if r.senderValidator != nil && sender != "" {
+		senderAddr := common.HexToAddress(sender)
 
-		if err != nil {
+		if err := r.senderValidator(senderAddr); err != nil {
 			http.Error(rw, "unauthorized sender", http.StatusUnauthorized)
 			return
 		}
+
+		ctx = contextWithSender(ctx, senderAddr)
 	}
 
-	data, err := hexutil.Decode(ccipReq.Data)
+	data, err := hexutil.Decode(dataHex)
 
 	if err != nil {
 		http.Error(rw, "invalid data field", http.StatusBadRequest)
@@ -151,19 +179,19 @@ func (r *CCIPReadResolver) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	inputs, err := registered.method.Inputs.UnpackValues(data[4:])
+	inputMap := make(map[string]interface{})
 
-	if err != nil {
+	if err := registered.method.Inputs.UnpackIntoMap(inputMap, data[4:]); err != nil {
 		http.Error(rw, "failed to unpack input parameters", http.StatusBadRequest)
 		return
 	}
 
 	var inputVars []Variable
 
-	for i, input := range inputs {
+	for _, arg := range registered.method.Inputs {
 		inputVars = append(inputVars, Variable{
-			Name:  registered.method.Inputs[i].Name,
-			Value: input,
+			Name:  arg.Name,
+			Value: inputMap[arg.Name],
 		})
 	}
 
@@ -172,14 +200,24 @@ func (r *CCIPReadResolver) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		Input:  inputVars,
 	}
 
-	outputs, err := registered.handler(ccipReadReq)
+	outputs, err := r.callHandler(ctx, registered.handler, ccipReadReq)
 
 	if err != nil {
 		http.Error(rw, "handler error: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	outputData, err := registered.method.Outputs.PackValues(outputs)
+	encoder := r.outputEncoder
+	if r.signer != nil && r.signer.key != nil {
+		encoder = SignedOutputEncoder(r.signer, registered.method, data)
+	}
+
+	var outputData []byte
+	if encoder != nil {
+		outputData, err = encoder(outputs)
+	} else {
+		outputData, err = registered.method.Outputs.PackValues(outputs)
+	}
 
 	if err != nil {
 		http.Error(rw, "failed to pack output parameters", http.StatusInternalServerError)
@@ -213,5 +251,11 @@ func NewCCIPReadResolver(options ...Option) *CCIPReadResolver {
 		option(r)
 	}
 
+	var handler http.Handler = http.HandlerFunc(r.route)
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+	r.chain = handler
+
 	return r
 }
@@ -0,0 +1,115 @@
+package ccip
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignedResponses enables the EIP-3668 "signed offchain data" response mode.
+//
+// When set, ServeHTTP no longer returns the raw ABI-encoded handler output.
+// Instead it wraps the output in the envelope expected by the standard
+// OffchainResolver/SignatureVerifier contracts:
+//
+//	(bytes result, uint64 expires, bytes sig)
+//
+// where sig is an ECDSA signature over:
+//
+//	keccak256(0x1900 || verifier || expires || keccak256(callData) || keccak256(result))
+//
+// verifier must match the address of the on-chain SignatureVerifier that
+// will recompute this digest, and ttl controls how long the signature
+// remains valid.
+func SignedResponses(signerKey *ecdsa.PrivateKey, ttl time.Duration) Option {
+	return func(r *CCIPReadResolver) {
+		if r.signer == nil {
+			r.signer = &responseSigner{}
+		}
+		r.signer.key = signerKey
+		r.signer.ttl = ttl
+	}
+}
+
+// VerifierAddress sets the address of the on-chain SignatureVerifier
+// contract that the signed digest is bound to. It is required when
+// SignedResponses is used.
+func VerifierAddress(addr common.Address) Option {
+	return func(r *CCIPReadResolver) {
+		if r.signer == nil {
+			r.signer = &responseSigner{}
+		}
+		r.signer.verifier = addr
+	}
+}
+
+type responseSigner struct {
+	key      *ecdsa.PrivateKey
+	verifier common.Address
+	ttl      time.Duration
+}
+
+var signedEnvelopeOutputs = abi.Arguments{
+	{Name: "result", Type: mustType("bytes")},
+	{Name: "expires", Type: mustType("uint64")},
+	{Name: "sig", Type: mustType("bytes")},
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, t, nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// SignedOutputEncoder builds an OutputEncoder that wraps the values packed
+// by method's outputs in a signed EIP-3668 envelope, ready to be returned
+// by the standard offchain-resolver contract pattern.
+func SignedOutputEncoder(signer *responseSigner, method *abi.Method, callData []byte) OutputEncoder {
+	return func(outputs []interface{}) ([]byte, error) {
+		result, err := method.Outputs.PackValues(outputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack output parameters: %w", err)
+		}
+
+		expires := uint64(time.Now().Add(signer.ttl).Unix())
+
+		digest, err := signedDigest(signer.verifier, expires, callData, result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute signed digest: %w", err)
+		}
+
+		sig, err := crypto.Sign(digest[:], signer.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign response: %w", err)
+		}
+		// go-ethereum's recovery id is 0/1; the standard SignatureVerifier
+		// expects the Ethereum-style 27/28 convention.
+		sig[64] += 27
+
+		return signedEnvelopeOutputs.Pack(result, expires, sig)
+	}
+}
+
+func signedDigest(verifier common.Address, expires uint64, callData, result []byte) (common.Hash, error) {
+	callDataHash := crypto.Keccak256(callData)
+	resultHash := crypto.Keccak256(result)
+
+	var expiresBytes [8]byte
+	binary.BigEndian.PutUint64(expiresBytes[:], expires)
+
+	packed := make([]byte, 0, 2+common.AddressLength+8+32+32)
+	packed = append(packed, 0x19, 0x00)
+	packed = append(packed, verifier.Bytes()...)
+	packed = append(packed, expiresBytes[:]...)
+	packed = append(packed, callDataHash...)
+	packed = append(packed, resultHash...)
+
+	return crypto.Keccak256Hash(packed), nil
+}
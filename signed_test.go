@@ -0,0 +1,92 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSignedResponsesOptionsMerge(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	addr := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	ttl := 5 * time.Minute
+
+	// VerifierAddress before SignedResponses must not be discarded.
+	r := NewCCIPReadResolver(VerifierAddress(addr), SignedResponses(key, ttl))
+	if r.signer == nil {
+		t.Fatal("expected signer to be configured")
+	}
+	if r.signer.verifier != addr {
+		t.Fatalf("verifier = %s, want %s", r.signer.verifier, addr)
+	}
+	if r.signer.key != key {
+		t.Fatal("signer key not set")
+	}
+	if r.signer.ttl != ttl {
+		t.Fatalf("ttl = %s, want %s", r.signer.ttl, ttl)
+	}
+
+	// SignedResponses before VerifierAddress must also retain both fields.
+	r2 := NewCCIPReadResolver(SignedResponses(key, ttl), VerifierAddress(addr))
+	if r2.signer.verifier != addr {
+		t.Fatalf("verifier = %s, want %s", r2.signer.verifier, addr)
+	}
+	if r2.signer.key != key {
+		t.Fatal("signer key not set")
+	}
+}
+
+func TestSignedOutputEncoderDigestRecovers(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	verifier := common.HexToAddress("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd")
+	signer := &responseSigner{key: key, verifier: verifier, ttl: time.Minute}
+
+	method, err := ParseFunction("function addr(bytes32 node) view returns (address)")
+	if err != nil {
+		t.Fatalf("failed to parse function: %v", err)
+	}
+
+	callData := []byte{0x01, 0x02, 0x03, 0x04}
+	encoder := SignedOutputEncoder(signer, method, callData)
+
+	envelope, err := encoder([]interface{}{common.HexToAddress("0x1111111111111111111111111111111111111111")})
+	if err != nil {
+		t.Fatalf("encoder failed: %v", err)
+	}
+
+	values, err := signedEnvelopeOutputs.Unpack(envelope)
+	if err != nil {
+		t.Fatalf("failed to unpack envelope: %v", err)
+	}
+
+	result := values[0].([]byte)
+	expires := values[1].(uint64)
+	sig := values[2].([]byte)
+
+	digest, err := signedDigest(verifier, expires, callData, result)
+	if err != nil {
+		t.Fatalf("failed to compute digest: %v", err)
+	}
+
+	// Undo the Ethereum-style 27/28 recovery id shift before recovering.
+	recoverySig := append([]byte{}, sig...)
+	recoverySig[64] -= 27
+
+	pub, err := crypto.SigToPub(digest[:], recoverySig)
+	if err != nil {
+		t.Fatalf("failed to recover public key: %v", err)
+	}
+
+	if got := crypto.PubkeyToAddress(*pub); got != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("recovered signer %s, want %s", got, crypto.PubkeyToAddress(key.PublicKey))
+	}
+}